@@ -0,0 +1,155 @@
+// Package clustermgmt holds the types shared across the cluster management
+// tooling, in particular the onboarding of new build farm clusters.
+package clustermgmt
+
+import "encoding/json"
+
+// ClusterInstall describes everything ci-tools needs to know about a single
+// managed cluster: its name, where its configuration lives in the release
+// repository, and the onboarding options that control how its manifests are
+// generated.
+type ClusterInstall struct {
+	ClusterName string
+	Onboard     Onboard
+}
+
+// Onboard holds the configuration consumed by the onboarding steps.
+type Onboard struct {
+	ReleaseRepo string
+
+	OSD       *bool
+	Hosted    *bool
+	Unmanaged *bool
+
+	Certificate Certificate
+}
+
+// KeyValue is a single label key/value pair, used where a config needs to
+// override a label that would otherwise be derived from a default.
+type KeyValue struct {
+	Key   string
+	Value string
+}
+
+// Certificate configures the certificateStep: the base domains and registry
+// hosts it cannot discover on its own, and the overrides operators can apply
+// to the certificates it generates.
+type Certificate struct {
+	BaseDomains              map[string]string
+	ImageRegistryPublicHosts map[string]string
+	ClusterIssuer            map[string]map[string]string
+	ProjectLabel             map[string]map[string]KeyValue
+
+	// AdditionalDNSNames injects extra SANs into the built-in apiserver-tls,
+	// apps-tls and registry-tls certificates, keyed by cluster name and then
+	// by certificate name, e.g. to add console.<cluster>.<baseDomain> to
+	// apps-tls without hand-editing the generated manifest.
+	AdditionalDNSNames map[string]map[string][]string
+
+	// AdditionalCertificates are extra cert-manager Certificates to emit
+	// alongside the built-in apiserver/apps/registry set, keyed by cluster
+	// name.
+	AdditionalCertificates map[string][]AdditionalCertificate
+
+	// Profile picks the IssuerProfile certificateStep uses for its
+	// ClusterIssuer and project-label defaults: "AWS", "GCP", "Azure" or
+	// "custom". When empty, the profile is auto-detected from the cluster's
+	// Infrastructure object.
+	Profile Platform `json:"profile,omitempty"`
+	// CustomIssuerProfile configures the "custom" profile; required when
+	// Profile is "custom".
+	CustomIssuerProfile *CustomIssuerProfile `json:"customIssuerProfile,omitempty"`
+
+	// Backend picks how certificates are obtained: "cert-manager" (the
+	// default) emits cert-manager.io/v1 Certificate manifests; "csr" instead
+	// drives the Kubernetes CertificateSigningRequest API, for clusters
+	// without cert-manager installed yet.
+	Backend string `json:"backend,omitempty"`
+	// CSR configures the "csr" backend.
+	CSR *CSRBackend `json:"csr,omitempty"`
+}
+
+// CSRBackend configures the Kubernetes CSR API certificate backend.
+type CSRBackend struct {
+	// SignerName defaults to kubernetes.io/kube-apiserver-client when empty.
+	SignerName string `json:"signerName,omitempty"`
+	// WaitForApproval blocks until the CSR is approved and signed before the
+	// resulting Secret manifest is written. Defaults to true: writing a
+	// Secret with an empty tls.crt because the CSR hadn't been signed yet
+	// would silently produce a broken manifest, so callers must opt out
+	// explicitly to get the non-blocking behavior.
+	WaitForApproval *bool `json:"waitForApproval,omitempty"`
+}
+
+// additionalCertificateKnownFields lists the json tags AdditionalCertificate
+// itself understands; everything else in a config entry is kept in Extra.
+var additionalCertificateKnownFields = []string{
+	"name", "namespace", "secretName", "issuer", "dnsNames", "duration", "renewBefore", "keyAlgorithm", "usages",
+}
+
+// AdditionalCertificate describes a user-defined cert-manager Certificate to
+// emit alongside the built-in ones. Config loading goes through
+// sigs.k8s.io/yaml, which converts YAML to JSON and unmarshals with
+// encoding/json, so unknown-field passthrough is implemented with a custom
+// (Un)MarshalJSON pair rather than a yaml.v2 type.
+type AdditionalCertificate struct {
+	Name         string   `json:"name"`
+	Namespace    string   `json:"namespace"`
+	SecretName   string   `json:"secretName"`
+	Issuer       string   `json:"issuer"`
+	DNSNames     []string `json:"dnsNames"`
+	Duration     string   `json:"duration,omitempty"`
+	RenewBefore  string   `json:"renewBefore,omitempty"`
+	KeyAlgorithm string   `json:"keyAlgorithm,omitempty"`
+	Usages       []string `json:"usages,omitempty"`
+
+	// Extra carries any fields this struct doesn't recognize through to the
+	// generated Certificate spec untouched, so operators aren't blocked on
+	// this struct growing a field for every cert-manager spec option.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// additionalCertificateAlias has the same fields as AdditionalCertificate
+// but none of its methods, breaking the infinite recursion a naive
+// json.Marshal/Unmarshal call on AdditionalCertificate itself would cause.
+type additionalCertificateAlias AdditionalCertificate
+
+func (c *AdditionalCertificate) UnmarshalJSON(data []byte) error {
+	var alias additionalCertificateAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for _, field := range additionalCertificateKnownFields {
+		delete(raw, field)
+	}
+
+	*c = AdditionalCertificate(alias)
+	if len(raw) > 0 {
+		c.Extra = raw
+	}
+	return nil
+}
+
+func (c AdditionalCertificate) MarshalJSON() ([]byte, error) {
+	base, err := json.Marshal(additionalCertificateAlias(c))
+	if err != nil {
+		return nil, err
+	}
+	if len(c.Extra) == 0 {
+		return base, nil
+	}
+
+	merged := map[string]json.RawMessage{}
+	if err := json.Unmarshal(base, &merged); err != nil {
+		return nil, err
+	}
+	for key, value := range c.Extra {
+		merged[key] = value
+	}
+	return json.Marshal(merged)
+}
@@ -0,0 +1,61 @@
+package clustermgmt
+
+import (
+	"encoding/json"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+func TestAdditionalCertificateRoundTrip(t *testing.T) {
+	input := []byte(`
+name: oauth-openshift
+namespace: openshift-authentication
+secretName: oauth-tls
+issuer: cert-issuer-aws
+dnsNames:
+- oauth.build01.ci.example.com
+privateKey:
+  rotationPolicy: Always
+`)
+
+	var cert AdditionalCertificate
+	if err := yaml.Unmarshal(input, &cert); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if cert.Name != "oauth-openshift" || cert.Namespace != "openshift-authentication" || cert.SecretName != "oauth-tls" {
+		t.Fatalf("known fields not decoded correctly: %+v", cert)
+	}
+	if len(cert.DNSNames) != 1 || cert.DNSNames[0] != "oauth.build01.ci.example.com" {
+		t.Fatalf("dnsNames not decoded correctly: %+v", cert.DNSNames)
+	}
+
+	raw, ok := cert.Extra["privateKey"]
+	if !ok {
+		t.Fatalf("expected unknown field 'privateKey' to survive in Extra, got: %+v", cert.Extra)
+	}
+	var privateKey map[string]string
+	if err := json.Unmarshal(raw, &privateKey); err != nil {
+		t.Fatalf("unmarshal extra field: %v", err)
+	}
+	if privateKey["rotationPolicy"] != "Always" {
+		t.Fatalf("expected rotationPolicy Always, got %q", privateKey["rotationPolicy"])
+	}
+
+	marshaled, err := yaml.Marshal(&cert)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var roundTripped AdditionalCertificate
+	if err := yaml.Unmarshal(marshaled, &roundTripped); err != nil {
+		t.Fatalf("unmarshal round-tripped output: %v", err)
+	}
+	if roundTripped.SecretName != cert.SecretName {
+		t.Fatalf("secretName did not survive round-trip: got %q", roundTripped.SecretName)
+	}
+	if _, ok := roundTripped.Extra["privateKey"]; !ok {
+		t.Fatalf("expected 'privateKey' to survive marshal round-trip, got: %+v", roundTripped.Extra)
+	}
+}
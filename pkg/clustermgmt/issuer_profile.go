@@ -0,0 +1,123 @@
+package clustermgmt
+
+import "fmt"
+
+// IssuerProfile abstracts the platform-specific defaults certificateStep
+// needs: which ClusterIssuer to fall back to, which label identifies the
+// owning project/subscription, and which solver hints (if any) a custom
+// issuer implementation might need. Built-in profiles cover the platforms
+// the CI fleet actually runs on; a "custom" profile lets operators onboard
+// a platform without one (e.g. IBMCloud/PowerVS) by spelling everything out
+// in config instead.
+type IssuerProfile interface {
+	// DefaultClusterIssuer is the ClusterIssuer to use for certName when the
+	// operator hasn't set an explicit override.
+	DefaultClusterIssuer(certName string) string
+	// ProjectLabel is the label key/value identifying which cloud
+	// project/subscription the issuer should solve DNS challenges in.
+	ProjectLabel(certName string) (key, value string)
+	// SolverHints are extra, profile-specific annotations or labels a
+	// ClusterIssuer's ACME solver may key off of (e.g. the hosted zone).
+	SolverHints(certName string) map[string]string
+}
+
+type awsIssuerProfile struct{}
+
+func (awsIssuerProfile) DefaultClusterIssuer(certName string) string { return "cert-issuer-aws" }
+func (awsIssuerProfile) ProjectLabel(certName string) (string, string) {
+	return "aws-project", "openshift-ci-infra"
+}
+func (awsIssuerProfile) SolverHints(certName string) map[string]string {
+	return map[string]string{"dns01-solver": "route53"}
+}
+
+type gcpIssuerProfile struct{}
+
+func (gcpIssuerProfile) DefaultClusterIssuer(certName string) string { return "cert-issuer-gcp" }
+func (gcpIssuerProfile) ProjectLabel(certName string) (string, string) {
+	return "gcp-project", "openshift-ci-infra"
+}
+func (gcpIssuerProfile) SolverHints(certName string) map[string]string {
+	return map[string]string{"dns01-solver": "clouddns"}
+}
+
+type azureIssuerProfile struct{}
+
+func (azureIssuerProfile) DefaultClusterIssuer(certName string) string { return "cert-issuer-azure" }
+func (azureIssuerProfile) ProjectLabel(certName string) (string, string) {
+	return "azure-project", "openshift-ci-infra"
+}
+func (azureIssuerProfile) SolverHints(certName string) map[string]string {
+	return map[string]string{"dns01-solver": "azuredns"}
+}
+
+// http01IssuerProfile is the generic fallback for platforms without a
+// supported DNS-01 solver: it relies on an HTTP-01 ClusterIssuer instead.
+type http01IssuerProfile struct{}
+
+func (http01IssuerProfile) DefaultClusterIssuer(certName string) string { return "cert-issuer-http01" }
+func (http01IssuerProfile) ProjectLabel(certName string) (string, string) {
+	return "project", "openshift-ci-infra"
+}
+func (http01IssuerProfile) SolverHints(certName string) map[string]string {
+	return map[string]string{"http01-solver": "ingress"}
+}
+
+// CustomIssuerProfile is a fully config-driven IssuerProfile, for platforms
+// with no built-in profile.
+type CustomIssuerProfile struct {
+	ClusterIssuer     map[string]string            `json:"clusterIssuer"`
+	ProjectLabels     map[string]KeyValue          `json:"projectLabel"`
+	SolverHintsByCert map[string]map[string]string `json:"solverHints"`
+}
+
+func (p CustomIssuerProfile) DefaultClusterIssuer(certName string) string {
+	return p.ClusterIssuer[certName]
+}
+
+func (p CustomIssuerProfile) ProjectLabel(certName string) (string, string) {
+	kv := p.ProjectLabels[certName]
+	return kv.Key, kv.Value
+}
+
+func (p CustomIssuerProfile) SolverHints(certName string) map[string]string {
+	return p.SolverHintsByCert[certName]
+}
+
+// Platform identifies the cloud or on-prem platform a cluster runs on, used
+// to pick an IssuerProfile. It intentionally mirrors the values OpenShift's
+// Infrastructure.status.platformStatus.type uses so a profile can be
+// auto-detected without re-deriving platform names.
+type Platform string
+
+const (
+	AWSPlatform    Platform = "AWS"
+	GCPPlatform    Platform = "GCP"
+	AzurePlatform  Platform = "Azure"
+	NonePlatform   Platform = "None"
+	CustomPlatform Platform = "custom"
+)
+
+// IssuerProfileFor resolves the IssuerProfile for a platform, falling back
+// to the generic HTTP-01 profile for unrecognized or on-prem platforms.
+// custom is used only when platform is CustomPlatform, and is ignored for
+// every other platform; CustomPlatform with a nil custom is an error.
+func IssuerProfileFor(platform Platform, custom *CustomIssuerProfile) (IssuerProfile, error) {
+	if platform == CustomPlatform {
+		if custom == nil {
+			return nil, fmt.Errorf("custom issuer profile requested but not configured")
+		}
+		return *custom, nil
+	}
+
+	switch platform {
+	case AWSPlatform:
+		return awsIssuerProfile{}, nil
+	case GCPPlatform:
+		return gcpIssuerProfile{}, nil
+	case AzurePlatform:
+		return azureIssuerProfile{}, nil
+	default:
+		return http01IssuerProfile{}, nil
+	}
+}
@@ -2,6 +2,7 @@ package onboard
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -14,6 +15,7 @@ import (
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
+	configv1 "github.com/openshift/api/config/v1"
 	imagev1 "github.com/openshift/api/image/v1"
 	"github.com/openshift/library-go/pkg/image/reference"
 
@@ -26,6 +28,11 @@ type certificateStep struct {
 	clusterInstall *clustermgmt.ClusterInstall
 	kubeClient     KubeClientGetter
 	writeManifest  func(name string, data []byte, perm fs.FileMode) error
+
+	// issuerProfile is resolved in Run before manifest generation and used by
+	// clusterIssuerOrDefault/projectLabelOrDefault for their platform
+	// defaults.
+	issuerProfile clustermgmt.IssuerProfile
 }
 
 func (s *certificateStep) Name() string {
@@ -50,6 +57,12 @@ func (s *certificateStep) Run(ctx context.Context) error {
 		return fmt.Errorf("image registry public host: %w", err)
 	}
 
+	profile, err := s.resolveIssuerProfile(ctx, client)
+	if err != nil {
+		return fmt.Errorf("issuer profile: %w", err)
+	}
+	s.issuerProfile = profile
+
 	manifests := s.generateCertificateManifests(baseDomain, host)
 	manifestMarshaled, err := citoolsyaml.MarshalMultidoc(yaml.Marshal, manifests...)
 	if err != nil {
@@ -66,9 +79,17 @@ func (s *certificateStep) Run(ctx context.Context) error {
 }
 
 func (s *certificateStep) baseDomain(ctx context.Context, client ctrlruntimeclient.Client) (string, error) {
-	if s.clusterInstall.Onboard.Certificate.BaseDomains != nil {
-		if domain, ok := s.clusterInstall.Onboard.Certificate.BaseDomains[s.clusterInstall.ClusterName]; ok {
-			s.log.Info("override base domain from config")
+	return baseDomain(ctx, client, s.log, s.clusterInstall)
+}
+
+// baseDomain resolves the cluster's base domain, honoring a per-cluster
+// override before falling back to the live install-config. It is shared by
+// certificateStep and csrCertificateStep so both backends derive the same
+// DNS names.
+func baseDomain(ctx context.Context, client ctrlruntimeclient.Client, log *logrus.Entry, clusterInstall *clustermgmt.ClusterInstall) (string, error) {
+	if clusterInstall.Onboard.Certificate.BaseDomains != nil {
+		if domain, ok := clusterInstall.Onboard.Certificate.BaseDomains[clusterInstall.ClusterName]; ok {
+			log.Info("override base domain from config")
 			return domain, nil
 		}
 	}
@@ -92,9 +113,17 @@ func (s *certificateStep) baseDomain(ctx context.Context, client ctrlruntimeclie
 }
 
 func (s *certificateStep) imageRegistryPublicHost(ctx context.Context, client ctrlruntimeclient.Client) (string, error) {
-	if s.clusterInstall.Onboard.Certificate.ImageRegistryPublicHosts != nil {
-		if publicHost, ok := s.clusterInstall.Onboard.Certificate.ImageRegistryPublicHosts[s.clusterInstall.ClusterName]; ok {
-			s.log.Info("override image registry public host from config")
+	return imageRegistryPublicHost(ctx, client, s.log, s.clusterInstall)
+}
+
+// imageRegistryPublicHost resolves the cluster's public registry hostname,
+// honoring a per-cluster override before falling back to the live
+// ImageStream list. It is shared by certificateStep and csrCertificateStep
+// so both backends derive the same DNS name for registry-tls.
+func imageRegistryPublicHost(ctx context.Context, client ctrlruntimeclient.Client, log *logrus.Entry, clusterInstall *clustermgmt.ClusterInstall) (string, error) {
+	if clusterInstall.Onboard.Certificate.ImageRegistryPublicHosts != nil {
+		if publicHost, ok := clusterInstall.Onboard.Certificate.ImageRegistryPublicHosts[clusterInstall.ClusterName]; ok {
+			log.Info("override image registry public host from config")
 			return publicHost, nil
 		}
 	}
@@ -117,107 +146,214 @@ func (s *certificateStep) imageRegistryPublicHost(ctx context.Context, client ct
 	return "", fmt.Errorf("no public registry host could be located")
 }
 
-func (s *certificateStep) generateCertificateManifests(baseDomain, imageRegistryHost string) []interface{} {
-	manifests := make([]interface{}, 0)
+// resolveIssuerProfile honors an explicit Onboard.Certificate.Profile, or
+// auto-detects one from the cluster's Infrastructure object otherwise.
+func (s *certificateStep) resolveIssuerProfile(ctx context.Context, client ctrlruntimeclient.Client) (clustermgmt.IssuerProfile, error) {
+	platform := s.clusterInstall.Onboard.Certificate.Profile
+	if platform == "" {
+		infra := configv1.Infrastructure{}
+		if err := client.Get(ctx, types.NamespacedName{Name: "cluster"}, &infra); err != nil {
+			return nil, fmt.Errorf("get infrastructure: %w", err)
+		}
+		if infra.Status.PlatformStatus != nil {
+			platform = clustermgmt.Platform(infra.Status.PlatformStatus.Type)
+		}
+	}
+	return clustermgmt.IssuerProfileFor(platform, s.clusterInstall.Onboard.Certificate.CustomIssuerProfile)
+}
 
-	projLabelKey, projLabelValue := s.projectLabelOrDefault("apiserver-tls", "aws-project", "openshift-ci-infra")
-	apiServerCert := map[string]interface{}{
-		"kind": "Certificate",
-		"metadata": map[string]interface{}{
-			"labels": map[string]interface{}{
-				projLabelKey: projLabelValue,
-			},
-			"name":      "apiserver-tls",
-			"namespace": "openshift-config",
-		},
-		"spec": map[string]interface{}{
-			"dnsNames": []interface{}{
-				fmt.Sprintf("api.%s.%s", s.clusterInstall.ClusterName, baseDomain),
+// builtinCertificateSpec identifies one of the built-in apiserver/apps/
+// registry certificates: its name, namespace, secret and DNS names
+// (including any additional SANs from config). It is shared by the
+// cert-manager and CSR backends so both generate the same set of
+// certificates for a cluster.
+type builtinCertificateSpec struct {
+	Name       string
+	Namespace  string
+	SecretName string
+	DNSNames   []string
+}
+
+// builtinCertificateSpecs returns the apiserver-tls/apps-tls certificates
+// (skipped for OSD/Hosted/Unmanaged clusters, which don't own their own
+// control plane) and the registry-tls certificate, in that order.
+func builtinCertificateSpecs(clusterInstall *clustermgmt.ClusterInstall, baseDomain, imageRegistryHost string) []builtinCertificateSpec {
+	specs := make([]builtinCertificateSpec, 0, 3)
+
+	if !(*clusterInstall.Onboard.OSD || *clusterInstall.Onboard.Hosted || *clusterInstall.Onboard.Unmanaged) {
+		specs = append(specs,
+			builtinCertificateSpec{
+				Name:       "apiserver-tls",
+				Namespace:  "openshift-config",
+				SecretName: "apiserver-tls",
+				DNSNames:   additionalDNSNames(clusterInstall, "apiserver-tls", fmt.Sprintf("api.%s.%s", clusterInstall.ClusterName, baseDomain)),
 			},
-			"issuerRef": map[string]interface{}{
-				"kind": "ClusterIssuer",
-				"name": s.clusterIssuerOrDefault("apiserver-tls", "cert-issuer-aws"),
+			builtinCertificateSpec{
+				Name:       "apps-tls",
+				Namespace:  "openshift-ingress",
+				SecretName: "apps-tls",
+				DNSNames:   additionalDNSNames(clusterInstall, "apps-tls", fmt.Sprintf("*.apps.%s.%s", clusterInstall.ClusterName, baseDomain)),
 			},
-			"secretName": "apiserver-tls",
-		},
-		"apiVersion": "cert-manager.io/v1",
+		)
 	}
 
-	projLabelKey, projLabelValue = s.projectLabelOrDefault("apps-tls", "aws-project", "openshift-ci-infra")
-	appsCert := map[string]interface{}{
-		"apiVersion": "cert-manager.io/v1",
-		"kind":       "Certificate",
-		"metadata": map[string]interface{}{
-			"labels": map[string]interface{}{
-				projLabelKey: projLabelValue,
-			},
-			"name":      "apps-tls",
-			"namespace": "openshift-ingress",
-		},
-		"spec": map[string]interface{}{
-			"dnsNames": []interface{}{
-				fmt.Sprintf("*.apps.%s.%s", s.clusterInstall.ClusterName, baseDomain),
-			},
-			"issuerRef": map[string]interface{}{
-				"kind": "ClusterIssuer",
-				"name": s.clusterIssuerOrDefault("apps-tls", "cert-issuer-aws"),
-			},
-			"secretName": "apps-tls",
-		},
+	specs = append(specs, builtinCertificateSpec{
+		Name:       "registry-tls",
+		Namespace:  "openshift-image-registry",
+		SecretName: "public-route-tls",
+		DNSNames:   additionalDNSNames(clusterInstall, "registry-tls", imageRegistryHost),
+	})
+
+	return specs
+}
+
+// additionalDNSNames returns dnsName together with any extra SANs
+// configured for this cluster and certificate via
+// Onboard.Certificate.AdditionalDNSNames.
+func additionalDNSNames(clusterInstall *clustermgmt.ClusterInstall, certificate, dnsName string) []string {
+	dnsNames := []string{dnsName}
+	additional := clusterInstall.Onboard.Certificate.AdditionalDNSNames
+	if cluster, ok := additional[clusterInstall.ClusterName]; ok {
+		dnsNames = append(dnsNames, cluster[certificate]...)
 	}
+	return dnsNames
+}
 
-	projLabelKey, projLabelValue = s.projectLabelOrDefault("registry-tls", "gcp-project", "openshift-ci-infra")
-	imageRegistryCert := map[string]interface{}{
-		"apiVersion": "cert-manager.io/v1",
-		"kind":       "Certificate",
-		"metadata": map[string]interface{}{
+func (s *certificateStep) generateCertificateManifests(baseDomain, imageRegistryHost string) []interface{} {
+	manifests := make([]interface{}, 0)
+
+	for _, spec := range builtinCertificateSpecs(s.clusterInstall, baseDomain, imageRegistryHost) {
+		projLabelKey, projLabelValue := s.projectLabelOrDefault(spec.Name)
+		dnsNames := make([]interface{}, 0, len(spec.DNSNames))
+		for _, dnsName := range spec.DNSNames {
+			dnsNames = append(dnsNames, dnsName)
+		}
+
+		metadata := map[string]interface{}{
 			"labels": map[string]interface{}{
 				projLabelKey: projLabelValue,
 			},
-			"name":      "registry-tls",
-			"namespace": "openshift-image-registry",
-		},
-		"spec": map[string]interface{}{
-			"dnsNames": []interface{}{
-				imageRegistryHost,
+			"name":      spec.Name,
+			"namespace": spec.Namespace,
+		}
+		if hints := s.issuerProfile.SolverHints(spec.Name); len(hints) > 0 {
+			annotations := make(map[string]interface{}, len(hints))
+			for key, value := range hints {
+				annotations[key] = value
+			}
+			metadata["annotations"] = annotations
+		}
+
+		manifests = append(manifests, map[string]interface{}{
+			"apiVersion": "cert-manager.io/v1",
+			"kind":       "Certificate",
+			"metadata":   metadata,
+			"spec": map[string]interface{}{
+				"dnsNames": dnsNames,
+				"issuerRef": map[string]interface{}{
+					"kind": "ClusterIssuer",
+					"name": s.clusterIssuerOrDefault(spec.Name),
+				},
+				"secretName": spec.SecretName,
 			},
+		})
+	}
+
+	manifests = append(manifests, s.additionalCertificateManifests()...)
+
+	return manifests
+}
+
+// additionalCertificateManifests renders the user-defined certificates for
+// this cluster, configured via Onboard.Certificate.AdditionalCertificates.
+// Unknown fields on each entry are passed through untouched.
+func (s *certificateStep) additionalCertificateManifests() []interface{} {
+	manifests := make([]interface{}, 0)
+	for _, cert := range s.clusterInstall.Onboard.Certificate.AdditionalCertificates[s.clusterInstall.ClusterName] {
+		dnsNames := make([]interface{}, 0, len(cert.DNSNames))
+		for _, name := range cert.DNSNames {
+			dnsNames = append(dnsNames, name)
+		}
+
+		spec := map[string]interface{}{
+			"dnsNames": dnsNames,
 			"issuerRef": map[string]interface{}{
 				"kind": "ClusterIssuer",
-				"name": s.clusterIssuerOrDefault("registry-tls", "cert-issuer"),
+				"name": cert.Issuer,
 			},
-			"secretName": "public-route-tls",
-		},
-	}
+			"secretName": cert.SecretName,
+		}
+		if cert.Duration != "" {
+			spec["duration"] = cert.Duration
+		}
+		if cert.RenewBefore != "" {
+			spec["renewBefore"] = cert.RenewBefore
+		}
+		if cert.KeyAlgorithm != "" {
+			spec["privateKey"] = map[string]interface{}{"algorithm": cert.KeyAlgorithm}
+		}
+		if len(cert.Usages) > 0 {
+			usages := make([]interface{}, 0, len(cert.Usages))
+			for _, usage := range cert.Usages {
+				usages = append(usages, usage)
+			}
+			spec["usages"] = usages
+		}
+		for key, raw := range cert.Extra {
+			var value interface{}
+			if err := json.Unmarshal(raw, &value); err != nil {
+				s.log.WithError(err).WithField("field", key).Warn("ignoring unparseable extra certificate field")
+				continue
+			}
+			spec[key] = value
+		}
 
-	if !(*s.clusterInstall.Onboard.OSD || *s.clusterInstall.Onboard.Hosted || *s.clusterInstall.Onboard.Unmanaged) {
-		manifests = append(manifests, apiServerCert, appsCert)
+		manifests = append(manifests, map[string]interface{}{
+			"apiVersion": "cert-manager.io/v1",
+			"kind":       "Certificate",
+			"metadata": map[string]interface{}{
+				"name":      cert.Name,
+				"namespace": cert.Namespace,
+			},
+			"spec": spec,
+		})
 	}
-	manifests = append(manifests, imageRegistryCert)
-
 	return manifests
 }
 
-func (s *certificateStep) clusterIssuerOrDefault(certificate, def string) string {
+func (s *certificateStep) clusterIssuerOrDefault(certificate string) string {
 	ci := s.clusterInstall.Onboard.Certificate.ClusterIssuer
 	if cluster, ok := ci[s.clusterInstall.ClusterName]; ok {
 		if clusterIssuer, ok := cluster[certificate]; ok {
 			return clusterIssuer
 		}
 	}
-	return def
+	return s.issuerProfile.DefaultClusterIssuer(certificate)
 }
 
-func (s *certificateStep) projectLabelOrDefault(certificate, defKey, defValue string) (string, string) {
+func (s *certificateStep) projectLabelOrDefault(certificate string) (string, string) {
 	ci := s.clusterInstall.Onboard.Certificate.ProjectLabel
 	if projLabel, ok := ci[s.clusterInstall.ClusterName]; ok {
 		if keyVal, ok := projLabel[certificate]; ok {
 			return keyVal.Key, keyVal.Value
 		}
 	}
-	return defKey, defValue
+	return s.issuerProfile.ProjectLabel(certificate)
 }
 
+// NewCertificateStep creates the certificate-generation step for
+// clusterInstall: the cert-manager backend by default, or the Kubernetes
+// CSR backend (NewCertificateCSRStep) when Onboard.Certificate.Backend is
+// "csr".
 func NewCertificateStep(log *logrus.Entry, clusterInstall *clustermgmt.ClusterInstall,
+	kubeClient KubeClientGetter) Step {
+	if clusterInstall.Onboard.Certificate.Backend == "csr" {
+		return NewCertificateCSRStep(log, clusterInstall, kubeClient)
+	}
+	return newCertManagerCertificateStep(log, clusterInstall, kubeClient)
+}
+
+func newCertManagerCertificateStep(log *logrus.Entry, clusterInstall *clustermgmt.ClusterInstall,
 	kubeClient KubeClientGetter) *certificateStep {
 	return &certificateStep{
 		log:            log,
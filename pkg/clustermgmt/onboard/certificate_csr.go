@@ -0,0 +1,196 @@
+package onboard
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/ci-tools/pkg/clustermgmt"
+	citoolsyaml "github.com/openshift/ci-tools/pkg/util/yaml"
+)
+
+const defaultSignerName = "kubernetes.io/kube-apiserver-client"
+
+// csrCertificateStep is the Kubernetes CSR API alternative to
+// certificateStep, for clusters that don't have cert-manager installed yet
+// (early bring-up, disconnected, or HyperShift hosted clusters). It produces
+// the same Secret manifest layout certificateStep's cert-manager Certificate
+// + controller would eventually materialize, so downstream tooling doesn't
+// need to know which backend produced it.
+type csrCertificateStep struct {
+	log            *logrus.Entry
+	clusterInstall *clustermgmt.ClusterInstall
+	kubeClient     KubeClientGetter
+	writeManifest  func(name string, data []byte, perm fs.FileMode) error
+}
+
+func (s *csrCertificateStep) Name() string {
+	return "certificate-csr"
+}
+
+func (s *csrCertificateStep) Run(ctx context.Context) error {
+	log := s.log.WithField("step", s.Name())
+
+	client, err := s.kubeClient()
+	if err != nil {
+		return fmt.Errorf("kube client: %w", err)
+	}
+
+	domain, err := baseDomain(ctx, client, s.log, s.clusterInstall)
+	if err != nil {
+		return fmt.Errorf("base domain: %w", err)
+	}
+
+	host, err := imageRegistryPublicHost(ctx, client, s.log, s.clusterInstall)
+	if err != nil {
+		return fmt.Errorf("image registry public host: %w", err)
+	}
+
+	manifests := make([]interface{}, 0)
+	for _, spec := range builtinCertificateSpecs(s.clusterInstall, domain, host) {
+		secret, err := s.requestCertificate(ctx, client, spec.SecretName, spec.Namespace, spec.DNSNames[0], spec.DNSNames)
+		if err != nil {
+			return fmt.Errorf("request %s: %w", spec.Name, err)
+		}
+		manifests = append(manifests, secret)
+	}
+
+	for _, cert := range s.clusterInstall.Onboard.Certificate.AdditionalCertificates[s.clusterInstall.ClusterName] {
+		if len(cert.DNSNames) == 0 {
+			return fmt.Errorf("additional certificate %s: no dnsNames configured", cert.Name)
+		}
+		secret, err := s.requestCertificate(ctx, client, cert.SecretName, cert.Namespace, cert.DNSNames[0], cert.DNSNames)
+		if err != nil {
+			return fmt.Errorf("request %s: %w", cert.Name, err)
+		}
+		manifests = append(manifests, secret)
+	}
+
+	manifestMarshaled, err := citoolsyaml.MarshalMultidoc(yaml.Marshal, manifests...)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	outputPath := CertificateManifestPath(s.clusterInstall.Onboard.ReleaseRepo, s.clusterInstall.ClusterName)
+	if err := s.writeManifest(outputPath, manifestMarshaled, 0644); err != nil {
+		return fmt.Errorf("write template %s: %w", outputPath, err)
+	}
+
+	log.WithField("certificate", outputPath).Info("certificates generated via CSR backend")
+	return nil
+}
+
+// requestCertificate generates a key and CSR for commonName/dnsNames,
+// submits it as a CertificateSigningRequest, waits for it to be approved
+// and signed unless Onboard.Certificate.CSR.WaitForApproval is explicitly
+// false, and returns the Secret manifest carrying the result. It refuses to
+// write a Secret whose tls.crt hasn't actually been signed yet.
+func (s *csrCertificateStep) requestCertificate(ctx context.Context, client ctrlruntimeclient.Client, secretName, namespace, commonName string, dnsNames []string) (*corev1.Secret, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate key: %w", err)
+	}
+
+	csrTemplate := x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: commonName},
+		DNSNames: dnsNames,
+	}
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &csrTemplate, key)
+	if err != nil {
+		return nil, fmt.Errorf("create certificate request: %w", err)
+	}
+	csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("marshal private key: %w", err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	signerName := defaultSignerName
+	waitForApproval := true
+	if csrConfig := s.clusterInstall.Onboard.Certificate.CSR; csrConfig != nil {
+		if csrConfig.SignerName != "" {
+			signerName = csrConfig.SignerName
+		}
+		if csrConfig.WaitForApproval != nil {
+			waitForApproval = *csrConfig.WaitForApproval
+		}
+	}
+
+	csr := certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("%s-%s", s.clusterInstall.ClusterName, secretName),
+		},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Request:    csrPEM,
+			SignerName: signerName,
+			Usages:     []certificatesv1.KeyUsage{certificatesv1.UsageDigitalSignature, certificatesv1.UsageKeyEncipherment, certificatesv1.UsageServerAuth},
+		},
+	}
+	if err := client.Create(ctx, &csr); err != nil {
+		return nil, fmt.Errorf("create csr: %w", err)
+	}
+
+	if waitForApproval {
+		if err := wait.PollUntilContextTimeout(ctx, 5*time.Second, 15*time.Minute, true, func(ctx context.Context) (bool, error) {
+			if err := client.Get(ctx, types.NamespacedName{Name: csr.Name}, &csr); err != nil {
+				return false, err
+			}
+			return len(csr.Status.Certificate) > 0, nil
+		}); err != nil {
+			return nil, fmt.Errorf("wait for signed certificate: %w", err)
+		}
+	} else {
+		if err := client.Get(ctx, types.NamespacedName{Name: csr.Name}, &csr); err != nil {
+			return nil, fmt.Errorf("get csr: %w", err)
+		}
+		if len(csr.Status.Certificate) == 0 {
+			return nil, fmt.Errorf("csr %s is not signed yet and waitForApproval is false: rerun once it's approved, or set waitForApproval to true", csr.Name)
+		}
+	}
+
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: namespace,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       csr.Status.Certificate,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	}, nil
+}
+
+// NewCertificateCSRStep creates the Kubernetes CSR API backend for
+// certificate generation, used in place of NewCertificateStep when
+// Onboard.Certificate.Backend is "csr".
+func NewCertificateCSRStep(log *logrus.Entry, clusterInstall *clustermgmt.ClusterInstall,
+	kubeClient KubeClientGetter) *csrCertificateStep {
+	return &csrCertificateStep{
+		log:            log,
+		clusterInstall: clusterInstall,
+		writeManifest:  os.WriteFile,
+		kubeClient:     kubeClient,
+	}
+}
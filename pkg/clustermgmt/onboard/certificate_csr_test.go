@@ -0,0 +1,93 @@
+package onboard
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/openshift/ci-tools/pkg/clustermgmt"
+)
+
+// fakeSignedCertificate is the certificate newFakeApprovingClient writes into
+// every CSR's status on create, simulating an approver + signer reacting to
+// a CSR synchronously, since the fake client has no controllers of its own.
+var fakeSignedCertificate = []byte("-----BEGIN CERTIFICATE-----\nfake\n-----END CERTIFICATE-----\n")
+
+func newFakeApprovingClient(t *testing.T) ctrlruntimeclient.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := certificatesv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add certificates/v1 to scheme: %v", err)
+	}
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c ctrlruntimeclient.WithWatch, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.CreateOption) error {
+			if err := c.Create(ctx, obj, opts...); err != nil {
+				return err
+			}
+			csr, ok := obj.(*certificatesv1.CertificateSigningRequest)
+			if !ok {
+				return nil
+			}
+			csr.Status.Certificate = fakeSignedCertificate
+			return c.Status().Update(ctx, csr)
+		},
+	}).Build()
+}
+
+func TestRequestCertificateApprovedCSR(t *testing.T) {
+	step := &csrCertificateStep{
+		log:            logrus.NewEntry(logrus.New()),
+		clusterInstall: &clustermgmt.ClusterInstall{ClusterName: "build01"},
+	}
+
+	secret, err := step.requestCertificate(context.Background(), newFakeApprovingClient(t),
+		"apiserver-tls", "openshift-config", "api.build01.ci.example.com", []string{"api.build01.ci.example.com"})
+	if err != nil {
+		t.Fatalf("requestCertificate: %v", err)
+	}
+
+	if string(secret.Data[corev1.TLSCertKey]) != string(fakeSignedCertificate) {
+		t.Fatalf("expected the signed certificate to be written, got: %q", secret.Data[corev1.TLSCertKey])
+	}
+	if len(secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		t.Fatalf("expected a private key to be written")
+	}
+	if secret.Namespace != "openshift-config" || secret.Name != "apiserver-tls" {
+		t.Fatalf("unexpected secret identity: %s/%s", secret.Namespace, secret.Name)
+	}
+}
+
+func TestRequestCertificateFailsLoudlyWhenUnsignedAndNotWaiting(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := certificatesv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add certificates/v1 to scheme: %v", err)
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	noWait := false
+	step := &csrCertificateStep{
+		log: logrus.NewEntry(logrus.New()),
+		clusterInstall: &clustermgmt.ClusterInstall{
+			ClusterName: "build01",
+			Onboard: clustermgmt.Onboard{
+				Certificate: clustermgmt.Certificate{
+					CSR: &clustermgmt.CSRBackend{WaitForApproval: &noWait},
+				},
+			},
+		},
+	}
+
+	if _, err := step.requestCertificate(context.Background(), client,
+		"apiserver-tls", "openshift-config", "api.build01.ci.example.com", []string{"api.build01.ci.example.com"}); err == nil {
+		t.Fatal("expected an error instead of writing a Secret with an empty tls.crt")
+	}
+}
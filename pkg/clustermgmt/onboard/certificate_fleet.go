@@ -0,0 +1,143 @@
+package onboard
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/ci-tools/pkg/clustermgmt"
+)
+
+// defaultFleetConcurrency bounds how many clusters FleetCertificateStep
+// processes at once, so a large fleet doesn't open hundreds of concurrent
+// kube clients.
+const defaultFleetConcurrency = 10
+
+// FleetCertificateStep runs certificateStep for many clusters in one
+// invocation, so the CI onboarding job can refresh every managed cluster's
+// certificate manifest in a single pass instead of one run per cluster.
+type FleetCertificateStep struct {
+	log             *logrus.Entry
+	clusterInstalls []*clustermgmt.ClusterInstall
+	kubeClientFor   func(clusterName string) KubeClientGetter
+	writeManifest   func(name string, data []byte, perm fs.FileMode) error
+	concurrency     int
+	continueOnError bool
+}
+
+func (s *FleetCertificateStep) Name() string {
+	return "certificate-fleet"
+}
+
+func (s *FleetCertificateStep) Run(ctx context.Context) error {
+	log := s.log.WithField("step", s.Name())
+
+	var mu sync.Mutex
+	failures := map[string]error{}
+
+	g, gctx := errgroup.WithContext(ctx)
+	sem := make(chan struct{}, s.concurrency)
+
+	for _, clusterInstall := range s.clusterInstalls {
+		clusterInstall := clusterInstall
+		g.Go(func() error {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			step := &certificateStep{
+				log:            log.WithField("cluster", clusterInstall.ClusterName),
+				clusterInstall: clusterInstall,
+				kubeClient:     s.kubeClientFor(clusterInstall.ClusterName),
+				writeManifest:  s.writeManifest,
+			}
+
+			if err := step.Run(gctx); err != nil {
+				mu.Lock()
+				failures[clusterInstall.ClusterName] = err
+				mu.Unlock()
+				if !s.continueOnError {
+					return fmt.Errorf("%s: %w", clusterInstall.ClusterName, err)
+				}
+			}
+			return nil
+		})
+	}
+
+	waitErr := g.Wait()
+
+	for cluster, err := range failures {
+		log.WithError(err).WithField("cluster", cluster).Error("certificate generation failed")
+	}
+	log.WithField("clusters", len(s.clusterInstalls)).WithField("failed", len(failures)).Info("fleet certificate generation complete")
+
+	if waitErr != nil {
+		return waitErr
+	}
+
+	if len(failures) > 0 && !s.continueOnError {
+		return fmt.Errorf("%d of %d clusters failed certificate generation", len(failures), len(s.clusterInstalls))
+	}
+	return nil
+}
+
+// LoadFleetClusterInstalls reads every cluster-install.yaml under dir (the
+// release repo's per-cluster onboard config layout, one subdirectory per
+// cluster) into a ClusterInstall, for use with NewFleetCertificateStep.
+func LoadFleetClusterInstalls(dir string) ([]*clustermgmt.ClusterInstall, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read dir %s: %w", dir, err)
+	}
+
+	clusterInstalls := make([]*clustermgmt.ClusterInstall, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name(), "cluster-install.yaml")
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("read %s: %w", path, err)
+		}
+
+		clusterInstall := clustermgmt.ClusterInstall{}
+		if err := yaml.Unmarshal(raw, &clusterInstall); err != nil {
+			return nil, fmt.Errorf("unmarshal %s: %w", path, err)
+		}
+		clusterInstalls = append(clusterInstalls, &clusterInstall)
+	}
+
+	return clusterInstalls, nil
+}
+
+// NewFleetCertificateStep creates the step that generates certificate
+// manifests for every cluster in clusterInstalls. kubeClientFor must return
+// a KubeClientGetter bound to the named cluster. concurrency <= 0 defaults
+// to defaultFleetConcurrency; continueOnError, when true, collects every
+// cluster's error into the final summary instead of failing at the first
+// one.
+func NewFleetCertificateStep(log *logrus.Entry, clusterInstalls []*clustermgmt.ClusterInstall,
+	kubeClientFor func(clusterName string) KubeClientGetter, concurrency int, continueOnError bool) *FleetCertificateStep {
+	if concurrency <= 0 {
+		concurrency = defaultFleetConcurrency
+	}
+	return &FleetCertificateStep{
+		log:             log,
+		clusterInstalls: clusterInstalls,
+		kubeClientFor:   kubeClientFor,
+		writeManifest:   os.WriteFile,
+		concurrency:     concurrency,
+		continueOnError: continueOnError,
+	}
+}
@@ -0,0 +1,108 @@
+package onboard
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/ci-tools/pkg/clustermgmt"
+)
+
+func fleetTestClusterInstall(name string) *clustermgmt.ClusterInstall {
+	falseVal := false
+	return &clustermgmt.ClusterInstall{
+		ClusterName: name,
+		Onboard: clustermgmt.Onboard{
+			OSD: &falseVal, Hosted: &falseVal, Unmanaged: &falseVal,
+			Certificate: clustermgmt.Certificate{
+				Profile:                  clustermgmt.AWSPlatform,
+				BaseDomains:              map[string]string{name: "ci.example.com"},
+				ImageRegistryPublicHosts: map[string]string{name: "registry." + name + ".ci.example.com"},
+			},
+		},
+	}
+}
+
+// TestFleetCertificateStepRunPrintsSummaryOnFailure reproduces the bug where
+// Run returned g.Wait()'s error immediately when continueOnError was false,
+// skipping the per-cluster failure log and the "fleet certificate
+// generation complete" summary.
+func TestFleetCertificateStepRunPrintsSummaryOnFailure(t *testing.T) {
+	var out bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&out)
+
+	var writeCalls sync.Map
+	writeManifest := func(name string, data []byte, perm fs.FileMode) error {
+		writeCalls.Store(name, true)
+		return nil
+	}
+
+	step := &FleetCertificateStep{
+		log:             logrus.NewEntry(logger),
+		clusterInstalls: []*clustermgmt.ClusterInstall{fleetTestClusterInstall("build01"), fleetTestClusterInstall("build02")},
+		kubeClientFor: func(clusterName string) KubeClientGetter {
+			if clusterName == "build02" {
+				return func() (ctrlruntimeclient.Client, error) { return nil, errors.New("no kubeconfig for build02") }
+			}
+			return func() (ctrlruntimeclient.Client, error) {
+				return fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build(), nil
+			}
+		},
+		writeManifest:   writeManifest,
+		concurrency:     defaultFleetConcurrency,
+		continueOnError: false,
+	}
+
+	if err := step.Run(context.Background()); err == nil {
+		t.Fatal("expected an error because build02 failed")
+	}
+
+	if !strings.Contains(out.String(), "fleet certificate generation complete") {
+		t.Fatalf("expected the summary to be logged even when continueOnError is false, got log output: %s", out.String())
+	}
+	if !strings.Contains(out.String(), "build02") {
+		t.Fatalf("expected build02's failure to be logged, got: %s", out.String())
+	}
+}
+
+func TestFleetCertificateStepRunContinuesOnError(t *testing.T) {
+	var writeCalls sync.Map
+	writeManifest := func(name string, data []byte, perm fs.FileMode) error {
+		writeCalls.Store(name, true)
+		return nil
+	}
+
+	step := &FleetCertificateStep{
+		log:             logrus.NewEntry(logrus.New()),
+		clusterInstalls: []*clustermgmt.ClusterInstall{fleetTestClusterInstall("build01"), fleetTestClusterInstall("build02")},
+		kubeClientFor: func(clusterName string) KubeClientGetter {
+			if clusterName == "build02" {
+				return func() (ctrlruntimeclient.Client, error) { return nil, errors.New("no kubeconfig for build02") }
+			}
+			return func() (ctrlruntimeclient.Client, error) {
+				return fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build(), nil
+			}
+		},
+		writeManifest:   writeManifest,
+		concurrency:     defaultFleetConcurrency,
+		continueOnError: true,
+	}
+
+	if err := step.Run(context.Background()); err != nil {
+		t.Fatalf("expected continueOnError to suppress the aggregate error, got: %v", err)
+	}
+	if _, ok := writeCalls.Load(CertificateManifestPath("", "build01")); !ok {
+		t.Fatalf("expected build01 to still succeed and write its manifest")
+	}
+}
@@ -0,0 +1,254 @@
+package onboard
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/ci-tools/pkg/clustermgmt"
+)
+
+// certificateRequestNameLabel is set by cert-manager on every
+// CertificateRequest it creates on behalf of a Certificate.
+const certificateRequestNameLabel = "cert-manager.io/certificate-name"
+
+// certificateRenewStep drives cert-manager against the live cluster: it
+// reports the Ready/notAfter/renewalTime status of the Certificates
+// certificateStep manages, and can force a renewal of any of them. It is an
+// opt-in step, run separately from manifest generation with
+// --only=certificate-renew.
+type certificateRenewStep struct {
+	log            *logrus.Entry
+	clusterInstall *clustermgmt.ClusterInstall
+	kubeClient     KubeClientGetter
+
+	// selector restricts the step to a subset of the managed Certificates:
+	// either an exact Certificate name, or a "key=value" label selector
+	// matched against the Certificate's labels. Empty selects all of them.
+	selector string
+	renew    bool
+	wait     bool
+}
+
+func (s *certificateRenewStep) Name() string {
+	return "certificate-renew"
+}
+
+func (s *certificateRenewStep) Run(ctx context.Context) error {
+	log := s.log.WithField("step", s.Name())
+
+	client, err := s.kubeClient()
+	if err != nil {
+		return fmt.Errorf("kube client: %w", err)
+	}
+
+	for _, name := range s.certificateNames() {
+		if !s.mayMatch(name) {
+			continue
+		}
+
+		cert := cmapi.Certificate{}
+		if err := client.Get(ctx, name, &cert); err != nil {
+			if apierrors.IsNotFound(err) && s.isLabelSelector() {
+				continue
+			}
+			return fmt.Errorf("get %s: %w", name, err)
+		}
+
+		if !s.matches(name, &cert) {
+			continue
+		}
+
+		if s.renew {
+			if err := s.renewCertificate(ctx, client, name); err != nil {
+				return fmt.Errorf("renew %s: %w", name, err)
+			}
+		}
+
+		if s.wait {
+			if err := s.waitForReady(ctx, client, name); err != nil {
+				return fmt.Errorf("wait for %s: %w", name, err)
+			}
+		}
+
+		if err := s.printStatus(ctx, client, log, name, &cert); err != nil {
+			return fmt.Errorf("status %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// matches reports whether a managed Certificate is selected by s.selector:
+// an exact Certificate name, a "key=value" label selector, or (when empty)
+// everything.
+func (s *certificateRenewStep) matches(name types.NamespacedName, cert *cmapi.Certificate) bool {
+	if s.selector == "" {
+		return true
+	}
+	if key, value, ok := strings.Cut(s.selector, "="); ok {
+		return cert.Labels[key] == value
+	}
+	return name.Name == s.selector
+}
+
+// mayMatch is a cheap pre-filter run before fetching a Certificate: it rules
+// out exact-name selectors that can't possibly match name, so a certificate
+// the selector was never asking about (e.g. not yet created on this
+// cluster) doesn't cause Run to fail before it even reaches the one the
+// selector targets. Label selectors and the empty (match-everything)
+// selector always pass through, since they can't be evaluated without the
+// object's labels.
+func (s *certificateRenewStep) mayMatch(name types.NamespacedName) bool {
+	if s.selector == "" || s.isLabelSelector() {
+		return true
+	}
+	return name.Name == s.selector
+}
+
+// isLabelSelector reports whether s.selector is a "key=value" label
+// selector rather than an exact Certificate name.
+func (s *certificateRenewStep) isLabelSelector() bool {
+	return strings.Contains(s.selector, "=")
+}
+
+// certificateNames returns the built-in apiserver/apps/registry certificates
+// plus any user-defined ones configured for this cluster.
+func (s *certificateRenewStep) certificateNames() []types.NamespacedName {
+	names := make([]types.NamespacedName, 0)
+	if !(*s.clusterInstall.Onboard.OSD || *s.clusterInstall.Onboard.Hosted || *s.clusterInstall.Onboard.Unmanaged) {
+		names = append(names,
+			types.NamespacedName{Name: "apiserver-tls", Namespace: "openshift-config"},
+			types.NamespacedName{Name: "apps-tls", Namespace: "openshift-ingress"},
+		)
+	}
+	names = append(names, types.NamespacedName{Name: "registry-tls", Namespace: "openshift-image-registry"})
+
+	for _, cert := range s.clusterInstall.Onboard.Certificate.AdditionalCertificates[s.clusterInstall.ClusterName] {
+		names = append(names, types.NamespacedName{Name: cert.Name, Namespace: cert.Namespace})
+	}
+
+	return names
+}
+
+// printStatus reports the same information as `cmctl status certificate`:
+// the Ready condition, notAfter/renewalTime, whether the target Secret
+// exists, and the state of the latest CertificateRequest.
+func (s *certificateRenewStep) printStatus(ctx context.Context, client ctrlruntimeclient.Client, log *logrus.Entry, name types.NamespacedName, cert *cmapi.Certificate) error {
+	entry := log.WithFields(logrus.Fields{
+		"certificate": name.String(),
+		"ready":       certificateReadyCondition(cert),
+	})
+	if cert.Status.NotAfter != nil {
+		entry = entry.WithField("notAfter", cert.Status.NotAfter.Time)
+	}
+	if cert.Status.RenewalTime != nil {
+		entry = entry.WithField("renewalTime", cert.Status.RenewalTime.Time)
+	}
+
+	secret := corev1.Secret{}
+	secretErr := client.Get(ctx, types.NamespacedName{Name: cert.Spec.SecretName, Namespace: name.Namespace}, &secret)
+	entry = entry.WithField("secretExists", secretErr == nil)
+
+	if req, err := s.latestCertificateRequest(ctx, client, name); err != nil {
+		return fmt.Errorf("list certificate requests: %w", err)
+	} else if req != nil {
+		entry = entry.WithField("lastRequest", req.Name).WithField("lastRequestState", certificateRequestReadyCondition(req))
+	}
+
+	entry.Info("certificate status")
+	return nil
+}
+
+// renewCertificate forces cert-manager to reissue a Certificate by deleting
+// its current CertificateRequest, mirroring `cmctl experimental renew`. A
+// Certificate with no CertificateRequest is left as it is, without an error.
+func (s *certificateRenewStep) renewCertificate(ctx context.Context, client ctrlruntimeclient.Client, name types.NamespacedName) error {
+	req, err := s.latestCertificateRequest(ctx, client, name)
+	if err != nil {
+		return fmt.Errorf("list certificate requests: %w", err)
+	}
+	if req == nil {
+		return nil
+	}
+	if err := client.Delete(ctx, req); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("delete certificate request: %w", err)
+	}
+	return nil
+}
+
+func (s *certificateRenewStep) waitForReady(ctx context.Context, client ctrlruntimeclient.Client, name types.NamespacedName) error {
+	return wait.PollUntilContextTimeout(ctx, 5*time.Second, 15*time.Minute, true, func(ctx context.Context) (bool, error) {
+		cert := cmapi.Certificate{}
+		if err := client.Get(ctx, name, &cert); err != nil {
+			return false, err
+		}
+		return certificateReadyCondition(&cert) == string(cmmeta.ConditionTrue), nil
+	})
+}
+
+// latestCertificateRequest returns the most recently created
+// CertificateRequest owned by the named Certificate, or nil if none exist.
+func (s *certificateRenewStep) latestCertificateRequest(ctx context.Context, client ctrlruntimeclient.Client, name types.NamespacedName) (*cmapi.CertificateRequest, error) {
+	reqs := cmapi.CertificateRequestList{}
+	if err := client.List(ctx, &reqs, ctrlruntimeclient.InNamespace(name.Namespace), ctrlruntimeclient.MatchingLabels{certificateRequestNameLabel: name.Name}); err != nil {
+		return nil, err
+	}
+	if len(reqs.Items) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(reqs.Items, func(i, j int) bool {
+		return reqs.Items[i].CreationTimestamp.After(reqs.Items[j].CreationTimestamp.Time)
+	})
+	return &reqs.Items[0], nil
+}
+
+func certificateReadyCondition(cert *cmapi.Certificate) string {
+	for _, cond := range cert.Status.Conditions {
+		if cond.Type == cmapi.CertificateConditionReady {
+			return string(cond.Status)
+		}
+	}
+	return string(cmmeta.ConditionUnknown)
+}
+
+func certificateRequestReadyCondition(req *cmapi.CertificateRequest) string {
+	for _, cond := range req.Status.Conditions {
+		if cond.Type == cmapi.CertificateRequestConditionReady {
+			if cond.Reason != "" {
+				return fmt.Sprintf("%s (%s)", cond.Status, cond.Reason)
+			}
+			return string(cond.Status)
+		}
+	}
+	return string(cmmeta.ConditionUnknown)
+}
+
+// NewCertificateRenewStep creates the opt-in step that reports on and
+// renews the Certificates certificateStep manages. selector, when non-empty,
+// restricts the step to a single Certificate name, or (if it contains "=")
+// to Certificates matching a "key=value" label.
+func NewCertificateRenewStep(log *logrus.Entry, clusterInstall *clustermgmt.ClusterInstall,
+	kubeClient KubeClientGetter, selector string, renew, waitForReady bool) *certificateRenewStep {
+	return &certificateRenewStep{
+		log:            log,
+		clusterInstall: clusterInstall,
+		kubeClient:     kubeClient,
+		selector:       selector,
+		renew:          renew,
+		wait:           waitForReady,
+	}
+}
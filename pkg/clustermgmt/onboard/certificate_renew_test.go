@@ -0,0 +1,142 @@
+package onboard
+
+import (
+	"context"
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/ci-tools/pkg/clustermgmt"
+)
+
+func newRenewTestClient(t *testing.T, objs ...ctrlruntimeclient.Object) ctrlruntimeclient.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := cmapi.AddToScheme(scheme); err != nil {
+		t.Fatalf("add certmanager/v1 to scheme: %v", err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func TestCertificateRenewStepMatches(t *testing.T) {
+	apiserverTLS := types.NamespacedName{Name: "apiserver-tls", Namespace: "openshift-config"}
+	labeledCert := &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"team": "ci"}}}
+
+	tests := []struct {
+		name     string
+		selector string
+		certName types.NamespacedName
+		cert     *cmapi.Certificate
+		want     bool
+	}{
+		{name: "empty selector matches everything", selector: "", certName: apiserverTLS, cert: &cmapi.Certificate{}, want: true},
+		{name: "exact name matches", selector: "apiserver-tls", certName: apiserverTLS, cert: &cmapi.Certificate{}, want: true},
+		{name: "exact name does not match", selector: "registry-tls", certName: apiserverTLS, cert: &cmapi.Certificate{}, want: false},
+		{name: "label selector matches", selector: "team=ci", certName: apiserverTLS, cert: labeledCert, want: true},
+		{name: "label selector does not match", selector: "team=other", certName: apiserverTLS, cert: labeledCert, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &certificateRenewStep{selector: tc.selector}
+			if got := s.matches(tc.certName, tc.cert); got != tc.want {
+				t.Fatalf("matches() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCertificateRenewStepCertificateNames(t *testing.T) {
+	falseVal := false
+	trueVal := true
+
+	s := &certificateRenewStep{
+		clusterInstall: &clustermgmt.ClusterInstall{
+			ClusterName: "build01",
+			Onboard: clustermgmt.Onboard{
+				OSD: &falseVal, Hosted: &falseVal, Unmanaged: &falseVal,
+				Certificate: clustermgmt.Certificate{
+					AdditionalCertificates: map[string][]clustermgmt.AdditionalCertificate{
+						"build01": {{Name: "oauth-openshift", Namespace: "openshift-authentication"}},
+					},
+				},
+			},
+		},
+	}
+	if names := s.certificateNames(); len(names) != 4 {
+		t.Fatalf("expected 4 certificate names, got %v", names)
+	}
+
+	s.clusterInstall.Onboard.OSD = &trueVal
+	if names := s.certificateNames(); len(names) != 2 {
+		t.Fatalf("expected apiserver-tls/apps-tls to be skipped for OSD, got %v", names)
+	}
+}
+
+// TestCertificateRenewStepRunSkipsOutOfSelectorNotFound reproduces the bug
+// where Run fetched every managed Certificate before applying the selector:
+// apiserver-tls and apps-tls don't exist on this cluster yet, and a selector
+// naming a different Certificate must not abort on their NotFound.
+func TestCertificateRenewStepRunSkipsOutOfSelectorNotFound(t *testing.T) {
+	registryTLS := &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Name: "registry-tls", Namespace: "openshift-image-registry"}}
+	client := newRenewTestClient(t, registryTLS)
+
+	falseVal := false
+	s := &certificateRenewStep{
+		log: logrus.NewEntry(logrus.New()),
+		clusterInstall: &clustermgmt.ClusterInstall{
+			ClusterName: "build01",
+			Onboard:     clustermgmt.Onboard{OSD: &falseVal, Hosted: &falseVal, Unmanaged: &falseVal},
+		},
+		kubeClient: func() (ctrlruntimeclient.Client, error) { return client, nil },
+		selector:   "registry-tls",
+	}
+
+	if err := s.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+}
+
+func TestCertificateRenewStepRenewCertificate(t *testing.T) {
+	name := types.NamespacedName{Name: "apiserver-tls", Namespace: "openshift-config"}
+	req := &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "apiserver-tls-1",
+			Namespace: name.Namespace,
+			Labels:    map[string]string{certificateRequestNameLabel: name.Name},
+		},
+	}
+	client := newRenewTestClient(t, req)
+
+	s := &certificateRenewStep{}
+	if err := s.renewCertificate(context.Background(), client, name); err != nil {
+		t.Fatalf("renewCertificate: %v", err)
+	}
+
+	err := client.Get(context.Background(), types.NamespacedName{Name: req.Name, Namespace: req.Namespace}, &cmapi.CertificateRequest{})
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("expected the certificate request to be deleted, got: %v", err)
+	}
+}
+
+func TestCertificateReadyCondition(t *testing.T) {
+	cert := &cmapi.Certificate{Status: cmapi.CertificateStatus{Conditions: []cmapi.CertificateCondition{
+		{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue},
+	}}}
+	if got := certificateReadyCondition(cert); got != string(cmmeta.ConditionTrue) {
+		t.Fatalf("certificateReadyCondition() = %q, want %q", got, cmmeta.ConditionTrue)
+	}
+
+	if got := certificateReadyCondition(&cmapi.Certificate{}); got != string(cmmeta.ConditionUnknown) {
+		t.Fatalf("certificateReadyCondition() on a cert with no conditions = %q, want %q", got, cmmeta.ConditionUnknown)
+	}
+}
@@ -0,0 +1,95 @@
+package onboard
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/ci-tools/pkg/clustermgmt"
+)
+
+// testIssuerProfile is a stand-in IssuerProfile so this test doesn't depend
+// on live Infrastructure auto-detection.
+type testIssuerProfile struct{}
+
+func (testIssuerProfile) DefaultClusterIssuer(string) string { return "cert-issuer-test" }
+func (testIssuerProfile) ProjectLabel(string) (string, string) {
+	return "test-project", "openshift-ci-infra"
+}
+func (testIssuerProfile) SolverHints(string) map[string]string { return nil }
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestGenerateCertificateManifestsRoundTrip(t *testing.T) {
+	clusterInstall := &clustermgmt.ClusterInstall{
+		ClusterName: "build01",
+		Onboard: clustermgmt.Onboard{
+			OSD: boolPtr(false), Hosted: boolPtr(false), Unmanaged: boolPtr(false),
+			Certificate: clustermgmt.Certificate{
+				AdditionalDNSNames: map[string]map[string][]string{
+					"build01": {"apps-tls": {"console.build01.ci.example.com"}},
+				},
+				AdditionalCertificates: map[string][]clustermgmt.AdditionalCertificate{
+					"build01": {{
+						Name:       "oauth-openshift",
+						Namespace:  "openshift-authentication",
+						SecretName: "oauth-tls",
+						Issuer:     "cert-issuer-aws",
+						DNSNames:   []string{"oauth.build01.ci.example.com"},
+					}},
+				},
+			},
+		},
+	}
+
+	s := &certificateStep{
+		log:            logrus.NewEntry(logrus.New()),
+		clusterInstall: clusterInstall,
+		issuerProfile:  testIssuerProfile{},
+	}
+
+	manifests := s.generateCertificateManifests("ci.example.com", "registry.ci.example.com")
+
+	marshaled, err := yaml.Marshal(manifests)
+	if err != nil {
+		t.Fatalf("marshal manifests: %v", err)
+	}
+
+	var roundTripped []map[string]interface{}
+	if err := yaml.Unmarshal(marshaled, &roundTripped); err != nil {
+		t.Fatalf("unmarshal manifests: %v", err)
+	}
+
+	byName := map[string]map[string]interface{}{}
+	for _, manifest := range roundTripped {
+		metadata, _ := manifest["metadata"].(map[string]interface{})
+		name, _ := metadata["name"].(string)
+		byName[name] = manifest
+	}
+
+	appsTLS, ok := byName["apps-tls"]
+	if !ok {
+		t.Fatalf("expected apps-tls manifest, got: %v", byName)
+	}
+	spec, _ := appsTLS["spec"].(map[string]interface{})
+	dnsNames, _ := spec["dnsNames"].([]interface{})
+	found := false
+	for _, dnsName := range dnsNames {
+		if dnsName == "console.build01.ci.example.com" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected apps-tls to carry the additional SAN, got dnsNames: %v", dnsNames)
+	}
+
+	oauthCert, ok := byName["oauth-openshift"]
+	if !ok {
+		t.Fatalf("expected user-defined oauth-openshift certificate, got: %v", byName)
+	}
+	oauthMetadata, _ := oauthCert["metadata"].(map[string]interface{})
+	if oauthMetadata["namespace"] != "openshift-authentication" {
+		t.Fatalf("expected oauth-openshift in openshift-authentication, got: %v", oauthMetadata)
+	}
+}
@@ -0,0 +1,11 @@
+package onboard
+
+import "context"
+
+// Step is implemented by every onboarding step; Steps and FleetStep return
+// it so callers can run cert-manager-backed and CSR-backed certificate
+// generation, renewal, and fleet-wide generation interchangeably.
+type Step interface {
+	Name() string
+	Run(ctx context.Context) error
+}
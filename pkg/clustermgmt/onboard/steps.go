@@ -0,0 +1,42 @@
+package onboard
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/ci-tools/pkg/clustermgmt"
+)
+
+// RenewOptions enables the opt-in certificate-renew step and carries the
+// flags an operator would pass via --only=certificate-renew: which
+// Certificates to target, and whether to force a renewal and/or wait for it
+// to complete.
+type RenewOptions struct {
+	Selector string
+	Renew    bool
+	Wait     bool
+}
+
+// Steps assembles the onboarding steps to run for clusterInstall:
+// certificate generation always, plus certificate-renew when renew is
+// non-nil, i.e. when an operator asked for it with --only=certificate-renew.
+func Steps(log *logrus.Entry, clusterInstall *clustermgmt.ClusterInstall, kubeClient KubeClientGetter, renew *RenewOptions) []Step {
+	steps := []Step{NewCertificateStep(log, clusterInstall, kubeClient)}
+	if renew != nil {
+		steps = append(steps, NewCertificateRenewStep(log, clusterInstall, kubeClient, renew.Selector, renew.Renew, renew.Wait))
+	}
+	return steps
+}
+
+// FleetStep loads every cluster-install.yaml under releaseRepoDir and
+// returns the step that (re)generates all of their certificate manifests in
+// one pass, the entry point for the fleet-wide onboarding job.
+func FleetStep(log *logrus.Entry, releaseRepoDir string, kubeClientFor func(clusterName string) KubeClientGetter,
+	concurrency int, continueOnError bool) (Step, error) {
+	clusterInstalls, err := LoadFleetClusterInstalls(releaseRepoDir)
+	if err != nil {
+		return nil, fmt.Errorf("load cluster installs: %w", err)
+	}
+	return NewFleetCertificateStep(log, clusterInstalls, kubeClientFor, concurrency, continueOnError), nil
+}